@@ -0,0 +1,100 @@
+package manifests
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+var _ asset.Asset = (*CloudProviderConfig)(nil)
+
+// CloudProviderConfig generates the cloud-provider config file, consumed by
+// kube-controller-manager and kube-apiserver, for platforms that require
+// one. Content is produced for Azure and AWS only; it is empty for
+// OpenStack and for platforms with no cloud integration.
+type CloudProviderConfig struct {
+	ConfigData []byte
+}
+
+// azureCloudProviderConfig mirrors the subset of the azure.json schema
+// consumed by kube-controller-manager's Azure cloud provider.
+type azureCloudProviderConfig struct {
+	Cloud          string `json:"cloud"`
+	TenantID       string `json:"tenantId"`
+	SubscriptionID string `json:"subscriptionId"`
+	ResourceGroup  string `json:"resourceGroup"`
+	Location       string `json:"location"`
+}
+
+// Name returns a human friendly name for the asset.
+func (c *CloudProviderConfig) Name() string {
+	return "Cloud Provider Config"
+}
+
+// Dependencies returns the dependency of the this asset.
+func (c *CloudProviderConfig) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the cloud provider config for the target platform.
+func (c *CloudProviderConfig) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	result, err := cloudProviderConfigForPlatform(installConfig.Config)
+	if err != nil {
+		return err
+	}
+
+	c.ConfigData = result.Data
+
+	return nil
+}
+
+// cloudProviderConfigResult is the platform-specific content produced by
+// cloudProviderConfigForPlatform.
+type cloudProviderConfigResult struct {
+	Data []byte
+}
+
+// cloudProviderConfigForPlatform builds the cloud-provider config for
+// whichever platform cfg targets. It is kept free of the asset.Parents
+// plumbing so the platform-selection logic can be exercised directly in
+// tests.
+func cloudProviderConfigForPlatform(cfg *types.InstallConfig) (*cloudProviderConfigResult, error) {
+	result := &cloudProviderConfigResult{}
+	clusterName := cfg.ObjectMeta.Name
+
+	switch {
+	case cfg.Platform.Azure != nil:
+		data, err := json.MarshalIndent(&azureCloudProviderConfig{
+			Cloud:          "AzurePublicCloud",
+			TenantID:       cfg.Platform.Azure.TenantID,
+			SubscriptionID: cfg.Platform.Azure.SubscriptionID,
+			ResourceGroup:  fmt.Sprintf("%s-rg", clusterName),
+			Location:       cfg.Platform.Azure.Region,
+		}, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal azure cloud provider config")
+		}
+		result.Data = data
+	case cfg.Platform.AWS != nil:
+		result.Data = []byte(fmt.Sprintf("[Global]\nKubernetesClusterTag=%s\nKubernetesClusterID=%s\n", clusterName, cfg.ClusterID))
+	}
+	// OpenStack intentionally has no case here yet: Platform.OpenStack.Cloud
+	// is a clouds.yaml profile name, not a Keystone auth-url, and NetworkID
+	// is not a subnet ID, so there's no install-config field today that
+	// lets us build a cloud.conf the in-tree OpenStack cloud provider could
+	// actually authenticate or configure a load balancer with. Add this
+	// case once the install-config carries the real credential/subnet
+	// fields.
+
+	return result, nil
+}
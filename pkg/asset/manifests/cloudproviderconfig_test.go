@@ -0,0 +1,104 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+func TestCloudProviderConfigForPlatform(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   *types.InstallConfig
+		wantNil  bool
+		contains []string
+	}{
+		{
+			name: "azure",
+			config: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				Platform: types.Platform{
+					Azure: &azure.Platform{
+						Region:         "centralus",
+						TenantID:       "tenant-id",
+						SubscriptionID: "subscription-id",
+					},
+				},
+			},
+			contains: []string{
+				`"cloud": "AzurePublicCloud"`,
+				`"tenantId": "tenant-id"`,
+				`"subscriptionId": "subscription-id"`,
+				`"resourceGroup": "test-cluster-rg"`,
+				`"location": "centralus"`,
+			},
+		},
+		{
+			name: "aws",
+			config: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				ClusterID:  "cluster-id",
+				Platform: types.Platform{
+					AWS: &aws.Platform{},
+				},
+			},
+			contains: []string{
+				"KubernetesClusterTag=test-cluster",
+				"KubernetesClusterID=cluster-id",
+			},
+		},
+		{
+			// OpenStack has no case in cloudProviderConfigForPlatform yet:
+			// Platform.OpenStack.Cloud is a clouds.yaml profile name and
+			// NetworkID is not a subnet ID, so there's nothing to build a
+			// real cloud.conf from today. This should change to a non-nil
+			// result once the install-config carries the right fields.
+			name: "openstack produces no cloud provider config yet",
+			config: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				Platform: types.Platform{
+					OpenStack: &openstack.Platform{
+						Cloud:     "mycloud",
+						NetworkID: "network-id",
+					},
+				},
+			},
+			wantNil: true,
+		},
+		{
+			name: "no cloud provider config required",
+			config: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			},
+			wantNil: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := cloudProviderConfigForPlatform(tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.wantNil {
+				if len(result.Data) != 0 {
+					t.Errorf("expected no cloud provider config, got %q", result.Data)
+				}
+				return
+			}
+
+			for _, want := range tc.contains {
+				if !strings.Contains(string(result.Data), want) {
+					t.Errorf("expected config to contain %q, got:\n%s", want, result.Data)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package bootkube
+
+import "text/template"
+
+// ClusterEtcdOperatorCertSigner is the constant to represent contents of
+// cluster-etcd-operator-cert-signer.yaml file. It carries no secret
+// material of its own; it is a pointer manifest that tells
+// cluster-etcd-operator's certsignercontroller to mint the per-node etcd
+// peer/serving/metric certificates from the etcd-signer and
+// etcd-metric-signer secrets at bootstrap render time.
+var ClusterEtcdOperatorCertSigner = template.Must(template.New("cluster-etcd-operator-cert-signer.yaml").Parse(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cert-signer-controller-config
+  namespace: openshift-etcd-operator
+data:
+  render-at-bootstrap: "true"
+`))
@@ -0,0 +1,16 @@
+package bootkube
+
+import "text/template"
+
+// EtcdMetricClientSecret is the constant to represent contents of etcd-metric-client-secret.yaml file
+var EtcdMetricClientSecret = template.Must(template.New("etcd-metric-client-secret.yaml").Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: etcd-metric-client
+  namespace: kube-system
+type: Opaque
+data:
+  tls.crt: {{.EtcdMetricClientCert}}
+  tls.key: {{.EtcdMetricClientKey}}
+`))
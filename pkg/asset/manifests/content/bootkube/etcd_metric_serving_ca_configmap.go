@@ -0,0 +1,14 @@
+package bootkube
+
+import "text/template"
+
+// EtcdMetricServingCAConfigmap is the constant to represent contents of etcd-metric-serving-ca-configmap.yaml file
+var EtcdMetricServingCAConfigmap = template.Must(template.New("etcd-metric-serving-ca-configmap.yaml").Parse(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: etcd-metric-serving-ca
+  namespace: kube-system
+data:
+  ca-bundle.crt: {{.EtcdMetricCaCert}}
+`))
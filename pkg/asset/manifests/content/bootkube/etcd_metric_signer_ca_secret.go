@@ -0,0 +1,16 @@
+package bootkube
+
+import "text/template"
+
+// EtcdMetricSignerCASecret is the constant to represent contents of etcd-metric-signer-ca-secret.yaml file
+var EtcdMetricSignerCASecret = template.Must(template.New("etcd-metric-signer-ca-secret.yaml").Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: etcd-metric-signer
+  namespace: openshift-etcd
+type: Opaque
+data:
+  tls.crt: {{.EtcdMetricCaCert}}
+  tls.key: {{.EtcdMetricCaKey}}
+`))
@@ -0,0 +1,16 @@
+package bootkube
+
+import "text/template"
+
+// EtcdMetricSignerSecret is the constant to represent contents of etcd-metric-signer-secret.yaml file
+var EtcdMetricSignerSecret = template.Must(template.New("etcd-metric-signer-secret.yaml").Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: etcd-metric-signer
+  namespace: kube-system
+type: Opaque
+data:
+  tls.crt: {{.EtcdMetricSignerCert}}
+  tls.key: {{.EtcdMetricSignerKey}}
+`))
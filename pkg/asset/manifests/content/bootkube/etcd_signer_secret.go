@@ -0,0 +1,16 @@
+package bootkube
+
+import "text/template"
+
+// EtcdSignerSecret is the constant to represent contents of etcd-signer-secret.yaml file
+var EtcdSignerSecret = template.Must(template.New("etcd-signer-secret.yaml").Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: etcd-signer
+  namespace: openshift-etcd
+type: Opaque
+data:
+  tls.crt: {{.EtcdSignerCert}}
+  tls.key: {{.EtcdSignerKey}}
+`))
@@ -19,6 +19,15 @@ import (
 
 const (
 	manifestDir = "manifests"
+
+	// etcdCertSignerFeatureGate opts the rendered manifests into handing
+	// per-node etcd certificate issuance off to cluster-etcd-operator's
+	// certsignercontroller instead of baking static leaf certificates into
+	// the bootstrap bundle. It is read from install-config.yaml's
+	// FeatureGates so the decision travels with the install-config rather
+	// than with whoever's shell happened to run openshift-install. This is
+	// experimental while that render flow stabilizes upstream.
+	etcdCertSignerFeatureGate = "EtcdCertSigner"
 )
 
 var (
@@ -31,6 +40,16 @@ var (
 type Manifests struct {
 	KubeSysConfig *configurationObject
 	FileList      []*asset.File
+
+	// UserProvidedFiles are the manifests found under manifestDir on Load
+	// that aren't one of the installer-generated filenames, plus every file
+	// found under openshiftManifestDir. They're carried over untouched by
+	// Generate so a regenerate doesn't blow away operator customizations.
+	UserProvidedFiles []*asset.File
+
+	// Patches are the manifests.d/patches/*.yaml overlays applied to the
+	// generated manifests by Generate.
+	Patches []*asset.File
 }
 
 type genericData map[string]string
@@ -54,6 +73,10 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&tls.EtcdClientCertKey{},
 		&tls.MCSCertKey{},
 		&tls.KubeletCertKey{},
+		&tls.EtcdMetricCA{},
+		&tls.EtcdMetricSignerCertKey{},
+		&tls.EtcdMetricClientCertKey{},
+		&CloudProviderConfig{},
 	}
 }
 
@@ -81,6 +104,12 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	}
 	m.FileList = append(m.FileList, m.generateBootKubeManifests(dependencies)...)
 
+	patched, err := applyManifestPatches(m.FileList, m.Patches)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply manifest patches")
+	}
+	m.FileList = append(patched, m.UserProvidedFiles...)
+
 	return nil
 }
 
@@ -89,6 +118,19 @@ func (m *Manifests) Files() []*asset.File {
 	return m.FileList
 }
 
+// useEtcdCertSignerManifests reports whether etcd certificate rendering
+// should be delegated to cluster-etcd-operator's certsignercontroller
+// (true) rather than templated statically by the installer (false, the
+// default), as requested by the install-config's FeatureGates.
+func useEtcdCertSignerManifests(installConfig *installconfig.InstallConfig) bool {
+	for _, gate := range installConfig.Config.FeatureGates {
+		if gate == etcdCertSignerFeatureGate {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*asset.File {
 	installConfig := &installconfig.InstallConfig{}
 	etcdCA := &tls.EtcdCA{}
@@ -97,6 +139,10 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 	etcdClientCertKey := &tls.EtcdClientCertKey{}
 	rootCA := &tls.RootCA{}
 	serviceServingCA := &tls.ServiceServingCA{}
+	etcdMetricCA := &tls.EtcdMetricCA{}
+	etcdMetricSignerCertKey := &tls.EtcdMetricSignerCertKey{}
+	etcdMetricClientCertKey := &tls.EtcdMetricClientCertKey{}
+	cloudProviderConfig := &CloudProviderConfig{}
 	dependencies.Get(
 		installConfig,
 		etcdCA,
@@ -105,6 +151,10 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 		mcsCertKey,
 		rootCA,
 		serviceServingCA,
+		etcdMetricCA,
+		etcdMetricSignerCertKey,
+		etcdMetricClientCertKey,
+		cloudProviderConfig,
 	)
 
 	etcdEndpointHostnames := make([]string, installConfig.Config.MasterCount())
@@ -113,10 +163,18 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 	}
 
 	templateData := &bootkubeTemplateData{
-		Base64encodeCloudProviderConfig: "", // FIXME
+		Base64encodeCloudProviderConfig: base64.StdEncoding.EncodeToString(cloudProviderConfig.ConfigData),
 		EtcdCaCert:                      base64.StdEncoding.EncodeToString(etcdCA.Cert()),
 		EtcdClientCert:                  base64.StdEncoding.EncodeToString(etcdClientCertKey.Cert()),
 		EtcdClientKey:                   base64.StdEncoding.EncodeToString(etcdClientCertKey.Key()),
+		EtcdMetricCaCert:                base64.StdEncoding.EncodeToString(etcdMetricCA.Cert()),
+		EtcdMetricCaKey:                 base64.StdEncoding.EncodeToString(etcdMetricCA.Key()),
+		EtcdMetricSignerCert:            base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Cert()),
+		EtcdMetricSignerKey:             base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Key()),
+		EtcdMetricClientCert:            base64.StdEncoding.EncodeToString(etcdMetricClientCertKey.Cert()),
+		EtcdMetricClientKey:             base64.StdEncoding.EncodeToString(etcdMetricClientCertKey.Key()),
+		EtcdSignerCert:                  base64.StdEncoding.EncodeToString(etcdCA.Cert()),
+		EtcdSignerKey:                   base64.StdEncoding.EncodeToString(etcdCA.Key()),
 		KubeCaCert:                      base64.StdEncoding.EncodeToString(kubeCA.Cert()),
 		KubeCaKey:                       base64.StdEncoding.EncodeToString(kubeCA.Key()),
 		McsTLSCert:                      base64.StdEncoding.EncodeToString(mcsCertKey.Cert()),
@@ -142,7 +200,7 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 		"etcd-service-endpoints.yaml":                applyTemplateData(bootkube.EtcdServiceEndpointsKubeSystem, templateData),
 		"kube-system-configmap-etcd-serving-ca.yaml": applyTemplateData(bootkube.KubeSystemConfigmapEtcdServingCA, templateData),
 		"kube-system-configmap-root-ca.yaml":         applyTemplateData(bootkube.KubeSystemConfigmapRootCA, templateData),
-		"kube-system-secret-etcd-client.yaml":        applyTemplateData(bootkube.KubeSystemSecretEtcdClient, templateData),
+		"etcd-metric-serving-ca-configmap.yaml":      applyTemplateData(bootkube.EtcdMetricServingCAConfigmap, templateData),
 
 		"01-tectonic-namespace.yaml":                 []byte(bootkube.TectonicNamespace),
 		"03-openshift-web-console-namespace.yaml":    []byte(bootkube.OpenshiftWebConsoleNamespace),
@@ -154,6 +212,18 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 		"etcd-service.yaml":                          []byte(bootkube.EtcdServiceKubeSystem),
 	}
 
+	if useEtcdCertSignerManifests(installConfig) {
+		// cluster-etcd-operator mints the per-node peer/serving/metric
+		// certificates itself; the installer only hands it the signing CAs.
+		assetData["etcd-signer-secret.yaml"] = applyTemplateData(bootkube.EtcdSignerSecret, templateData)
+		assetData["etcd-metric-signer-ca-secret.yaml"] = applyTemplateData(bootkube.EtcdMetricSignerCASecret, templateData)
+		assetData["cluster-etcd-operator-cert-signer.yaml"] = applyTemplateData(bootkube.ClusterEtcdOperatorCertSigner, templateData)
+	} else {
+		assetData["kube-system-secret-etcd-client.yaml"] = applyTemplateData(bootkube.KubeSystemSecretEtcdClient, templateData)
+		assetData["etcd-metric-signer-secret.yaml"] = applyTemplateData(bootkube.EtcdMetricSignerSecret, templateData)
+		assetData["etcd-metric-client-secret.yaml"] = applyTemplateData(bootkube.EtcdMetricClientSecret, templateData)
+	}
+
 	files := make([]*asset.File, 0, len(assetData))
 	for name, data := range assetData {
 		files = append(files, &asset.File{
@@ -183,16 +253,31 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 		return false, nil
 	}
 
+	openshiftFileList, err := f.FetchByPattern(filepath.Join(openshiftManifestDir, "*"))
+	if err != nil {
+		return false, err
+	}
+
+	patchFileList, err := f.FetchByPattern(filepath.Join(manifestPatchDir, "*.yaml"))
+	if err != nil {
+		return false, err
+	}
+
 	kubeSysConfig := &configurationObject{}
 	var found bool
+	var userProvidedFiles []*asset.File
 	for _, file := range fileList {
-		if file.Filename == kubeSysConfigPath {
+		switch {
+		case file.Filename == kubeSysConfigPath:
 			if err := yaml.Unmarshal(file.Data, kubeSysConfig); err != nil {
 				return false, errors.Wrapf(err, "failed to unmarshal cluster-config.yaml")
 			}
 			found = true
+		case !isGeneratedManifest(file.Filename):
+			userProvidedFiles = append(userProvidedFiles, file)
 		}
 	}
+	userProvidedFiles = append(userProvidedFiles, openshiftFileList...)
 
 	if !found {
 		return false, nil
@@ -200,6 +285,8 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 	}
 
 	m.FileList, m.KubeSysConfig = fileList, kubeSysConfig
+	m.UserProvidedFiles = userProvidedFiles
+	m.Patches = patchFileList
 
 	return true, nil
 }
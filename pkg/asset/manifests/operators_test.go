@@ -0,0 +1,51 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestUseEtcdCertSignerManifests(t *testing.T) {
+	cases := []struct {
+		name         string
+		featureGates []string
+		want         bool
+	}{
+		{
+			name:         "gate absent",
+			featureGates: nil,
+			want:         false,
+		},
+		{
+			name:         "gate present",
+			featureGates: []string{"EtcdCertSigner"},
+			want:         true,
+		},
+		{
+			name:         "other gates present but not this one",
+			featureGates: []string{"SomeOtherFeature", "AnotherFeature"},
+			want:         false,
+		},
+		{
+			name:         "gate present alongside other gates",
+			featureGates: []string{"SomeOtherFeature", "EtcdCertSigner"},
+			want:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{
+					FeatureGates: tc.featureGates,
+				},
+			}
+
+			if got := useEtcdCertSignerManifests(installConfig); got != tc.want {
+				t.Errorf("useEtcdCertSignerManifests() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	// openshiftManifestDir is a sibling of manifestDir for user-provided
+	// manifests that should only ever be applied after the cluster is up
+	// (e.g. content the cluster-version-operator should not see at
+	// bootstrap time). Generate creates nothing new in it; the files Load
+	// finds there are carried over unchanged in Generate's FileList so they
+	// survive a re-render.
+	openshiftManifestDir = "openshift"
+
+	// manifestPatchDir holds user-authored overlays applied to the
+	// generated manifests before they're marshaled to disk.
+	manifestPatchDir = "manifests.d/patches"
+)
+
+// generatedManifestFilenames is the set of manifestDir-relative filenames
+// that Generate owns. Anything else found under
+// manifestDir or openshiftManifestDir on Load is treated as a
+// user-provided customization and preserved across Generate.
+var generatedManifestFilenames = map[string]bool{
+	"cluster-config.yaml":                        true,
+	"kube-cloud-config.yaml":                     true,
+	"machine-config-server-tls-secret.yaml":      true,
+	"openshift-service-signer-secret.yaml":       true,
+	"pull.json":                                  true,
+	"tectonic-network-operator.yaml":             true,
+	"cvo-overrides.yaml":                         true,
+	"legacy-cvo-overrides.yaml":                  true,
+	"etcd-service-endpoints.yaml":                true,
+	"kube-system-configmap-etcd-serving-ca.yaml": true,
+	"kube-system-configmap-root-ca.yaml":         true,
+	"etcd-metric-serving-ca-configmap.yaml":      true,
+	"kube-system-secret-etcd-client.yaml":        true,
+	"etcd-metric-signer-secret.yaml":             true,
+	"etcd-metric-client-secret.yaml":             true,
+	"etcd-signer-secret.yaml":                    true,
+	"etcd-metric-signer-ca-secret.yaml":          true,
+	"cluster-etcd-operator-cert-signer.yaml":     true,
+	"01-tectonic-namespace.yaml":                 true,
+	"03-openshift-web-console-namespace.yaml":    true,
+	"04-openshift-machine-config-operator.yaml":  true,
+	"05-openshift-cluster-api-namespace.yaml":    true,
+	"09-openshift-service-signer-namespace.yaml": true,
+	"app-version-kind.yaml":                      true,
+	"app-version-tectonic-network.yaml":          true,
+	"etcd-service.yaml":                          true,
+}
+
+// isGeneratedManifest reports whether filename (relative to manifestDir) is
+// owned by the installer and therefore left out of UserProvidedFiles on
+// Load, to be recreated by the next Generate rather than preserved verbatim.
+func isGeneratedManifest(filename string) bool {
+	return generatedManifestFilenames[filepath.Base(filename)]
+}
+
+// unstructuredObject is a loosely-typed Kubernetes object, good enough to
+// pull kind/namespace/name out of a manifest and to strategic-merge a patch
+// on top of it without needing the real API types on hand.
+type unstructuredObject map[string]interface{}
+
+func (u unstructuredObject) kind() string      { return stringField(u, "kind") }
+func (u unstructuredObject) name() string      { return stringField(nestedMap(u, "metadata"), "name") }
+func (u unstructuredObject) namespace() string { return stringField(nestedMap(u, "metadata"), "namespace") }
+
+func stringField(m unstructuredObject, key string) string {
+	if m == nil {
+		return ""
+	}
+	s, _ := m[key].(string)
+	return s
+}
+
+func nestedMap(u unstructuredObject, key string) unstructuredObject {
+	m, _ := u[key].(map[string]interface{})
+	return unstructuredObject(m)
+}
+
+// applyManifestPatches merges each patch in patchFiles onto the generated
+// manifest it targets, matched by kind/namespace/name. Files that aren't
+// valid Kubernetes objects (e.g. pull.json) are passed through untouched.
+func applyManifestPatches(files []*asset.File, patchFiles []*asset.File) ([]*asset.File, error) {
+	if len(patchFiles) == 0 {
+		return files, nil
+	}
+
+	patches := make([]unstructuredObject, 0, len(patchFiles))
+	for _, pf := range patchFiles {
+		patch := unstructuredObject{}
+		if err := yaml.Unmarshal(pf.Data, &patch); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal patch %s", pf.Filename)
+		}
+		patches = append(patches, patch)
+	}
+
+	patched := make([]*asset.File, 0, len(files))
+	for _, file := range files {
+		obj := unstructuredObject{}
+		if err := yaml.Unmarshal(file.Data, &obj); err != nil || obj.kind() == "" {
+			// Not a single Kubernetes object (e.g. pull.json); leave as-is.
+			patched = append(patched, file)
+			continue
+		}
+
+		changed := false
+		for _, patch := range patches {
+			if patch.kind() != obj.kind() || patch.name() != obj.name() || patch.namespace() != obj.namespace() {
+				continue
+			}
+			obj = mergeUnstructured(obj, patch)
+			changed = true
+		}
+
+		if !changed {
+			patched = append(patched, file)
+			continue
+		}
+
+		data, err := yaml.Marshal(map[string]interface{}(obj))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal patched manifest %s", file.Filename)
+		}
+		patched = append(patched, &asset.File{Filename: file.Filename, Data: data})
+	}
+
+	return patched, nil
+}
+
+// mergeUnstructured recursively merges src onto dst, following RFC 7396
+// JSON Merge Patch semantics: scalars and lists in src replace the
+// corresponding value in dst, nested objects are merged key by key, and a
+// null value in src deletes that key from dst rather than being stored
+// literally.
+func mergeUnstructured(dst, src unstructuredObject) unstructuredObject {
+	merged := unstructuredObject{}
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			// A dst value that is absent or not an object is merged as an
+			// empty object, so nulls nested in srcMap are dropped too.
+			dstMap, _ := merged[k].(map[string]interface{})
+			merged[k] = map[string]interface{}(mergeUnstructured(dstMap, srcMap))
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,169 @@
+package manifests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestMergeUnstructured(t *testing.T) {
+	cases := []struct {
+		name string
+		dst  unstructuredObject
+		src  unstructuredObject
+		want unstructuredObject
+	}{
+		{
+			name: "scalar is overridden",
+			dst:  unstructuredObject{"replicas": float64(1)},
+			src:  unstructuredObject{"replicas": float64(3)},
+			want: unstructuredObject{"replicas": float64(3)},
+		},
+		{
+			name: "nested object is merged key by key",
+			dst: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"name":   "foo",
+					"labels": map[string]interface{}{"a": "1"},
+				},
+			},
+			src: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"b": "2"},
+				},
+			},
+			want: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"name":   "foo",
+					"labels": map[string]interface{}{"a": "1", "b": "2"},
+				},
+			},
+		},
+		{
+			name: "null deletes the key instead of storing a literal null",
+			dst: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"a": "1", "b": "2"},
+				},
+			},
+			src: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"b": nil},
+				},
+			},
+			want: unstructuredObject{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"a": "1"},
+				},
+			},
+		},
+		{
+			name: "top-level null deletes the key",
+			dst:  unstructuredObject{"kind": "Secret", "data": map[string]interface{}{"k": "v"}},
+			src:  unstructuredObject{"data": nil},
+			want: unstructuredObject{"kind": "Secret"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeUnstructured(tc.dst, tc.src)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("mergeUnstructured() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyManifestPatches(t *testing.T) {
+	cm := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cvo-overrides
+  namespace: openshift-cluster-version
+data:
+  foo: bar
+`)
+
+	t.Run("no patches leaves files untouched", func(t *testing.T) {
+		files := []*asset.File{{Filename: "manifests/cvo-overrides.yaml", Data: cm}}
+		got, err := applyManifestPatches(files, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, files) {
+			t.Errorf("applyManifestPatches() = %#v, want %#v", got, files)
+		}
+	})
+
+	t.Run("matching patch is merged in by kind/namespace/name", func(t *testing.T) {
+		patch := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cvo-overrides
+  namespace: openshift-cluster-version
+data:
+  foo: baz
+  extra: added
+`)
+		files := []*asset.File{{Filename: "manifests/cvo-overrides.yaml", Data: cm}}
+		patches := []*asset.File{{Filename: "manifests.d/patches/cvo.yaml", Data: patch}}
+
+		got, err := applyManifestPatches(files, patches)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		obj := unstructuredObject{}
+		if err := yaml.Unmarshal(got[0].Data, &obj); err != nil {
+			t.Fatalf("failed to unmarshal patched manifest: %v", err)
+		}
+		data, ok := obj["data"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("patched manifest has no data map: %#v", obj)
+		}
+		if data["foo"] != "baz" || data["extra"] != "added" {
+			t.Errorf("patch was not applied, got data %#v", data)
+		}
+	})
+
+	t.Run("non-matching patch is ignored", func(t *testing.T) {
+		patch := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: some-other-configmap
+  namespace: openshift-cluster-version
+data:
+  foo: baz
+`)
+		files := []*asset.File{{Filename: "manifests/cvo-overrides.yaml", Data: cm}}
+		patches := []*asset.File{{Filename: "manifests.d/patches/cvo.yaml", Data: patch}}
+
+		got, err := applyManifestPatches(files, patches)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, files) {
+			t.Errorf("applyManifestPatches() = %#v, want untouched %#v", got, files)
+		}
+	})
+
+	t.Run("non-object files pass through untouched", func(t *testing.T) {
+		files := []*asset.File{{Filename: "manifests/pull.json", Data: []byte(`{"auths":{}}`)}}
+		patches := []*asset.File{{Filename: "manifests.d/patches/cvo.yaml", Data: []byte(`kind: ConfigMap`)}}
+
+		got, err := applyManifestPatches(files, patches)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, files) {
+			t.Errorf("applyManifestPatches() = %#v, want untouched %#v", got, files)
+		}
+	})
+}
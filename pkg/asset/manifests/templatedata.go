@@ -0,0 +1,30 @@
+package manifests
+
+// bootkubeTemplateData is the data used to replace values in the bootkube
+// manifest templates.
+type bootkubeTemplateData struct {
+	Base64encodeCloudProviderConfig string
+	CVOClusterID                    string
+	EtcdCaCert                      string
+	EtcdClientCert                  string
+	EtcdClientKey                   string
+	EtcdEndpointDNSSuffix           string
+	EtcdEndpointHostnames           []string
+	EtcdMetricCaCert                string
+	EtcdMetricCaKey                 string
+	EtcdMetricClientCert            string
+	EtcdMetricClientKey             string
+	EtcdMetricSignerCert            string
+	EtcdMetricSignerKey             string
+	EtcdSignerCert                  string
+	EtcdSignerKey                   string
+	KubeCaCert                      string
+	KubeCaKey                       string
+	McsTLSCert                      string
+	McsTLSKey                       string
+	PullSecret                      string
+	RootCaCert                      string
+	ServiceServingCaCert            string
+	ServiceServingCaKey             string
+	TectonicNetworkOperatorImage    string
+}
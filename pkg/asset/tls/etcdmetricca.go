@@ -0,0 +1,71 @@
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	etcdMetricCaCertFilename = "tls/etcd-metric-ca-bundle.crt"
+	etcdMetricCaKeyFilename  = "tls/etcd-metric-ca.key"
+)
+
+var _ asset.WritableAsset = (*EtcdMetricCA)(nil)
+
+// EtcdMetricCA is the asset that generates the etcd-metric-ca key/cert pair.
+// It is kept independent from EtcdCA so that the PKI backing Prometheus'
+// etcd metrics scraping can be rotated without touching the serving CA used
+// for client/peer traffic.
+type EtcdMetricCA struct {
+	CertKey
+}
+
+// Dependencies returns the dependency of the this asset.
+func (c *EtcdMetricCA) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates the cert/key pair.
+func (c *EtcdMetricCA) Generate(parents asset.Parents) error {
+	cfg := &CertCfg{
+		Subject:   pkix.Name{CommonName: "etcd-metric-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		Validity:  ValidityTenYears,
+		IsCA:      true,
+	}
+
+	ck, err := genCA(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate etcd-metric CA")
+	}
+	c.CertKey = ck
+
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (c *EtcdMetricCA) Name() string {
+	return "Certificate (etcd metric CA)"
+}
+
+// Load loads the cert/key pair from disk, if present.
+func (c *EtcdMetricCA) Load(f asset.FileFetcher) (bool, error) {
+	ck, ok, err := loadCertKey(f, etcdMetricCaCertFilename, etcdMetricCaKeyFilename)
+	if err != nil || !ok {
+		return false, err
+	}
+	c.CertKey = ck
+	return true, nil
+}
+
+// Files returns the files generated by the asset.
+func (c *EtcdMetricCA) Files() []*asset.File {
+	return []*asset.File{
+		{Filename: etcdMetricCaCertFilename, Data: c.Cert()},
+		{Filename: etcdMetricCaKeyFilename, Data: c.Key()},
+	}
+}
@@ -0,0 +1,75 @@
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	etcdMetricClientCertFilename = "tls/etcd-metric-client.crt"
+	etcdMetricClientKeyFilename  = "tls/etcd-metric-client.key"
+)
+
+var _ asset.WritableAsset = (*EtcdMetricClientCertKey)(nil)
+
+// EtcdMetricClientCertKey is the asset that generates the etcd-metric-client
+// key/cert pair used by Prometheus to authenticate to etcd's metrics
+// listener.
+type EtcdMetricClientCertKey struct {
+	CertKey
+}
+
+// Dependencies returns the dependency of the this asset.
+func (c *EtcdMetricClientCertKey) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&EtcdMetricCA{},
+	}
+}
+
+// Generate generates the cert/key pair.
+func (c *EtcdMetricClientCertKey) Generate(parents asset.Parents) error {
+	ca := &EtcdMetricCA{}
+	parents.Get(ca)
+
+	cfg := &CertCfg{
+		Subject:      pkix.Name{CommonName: "etcd-metric-client", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		Validity:     ValidityTenYears,
+	}
+
+	ck, err := genCertKey(cfg, &ca.CertKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate etcd-metric-client cert")
+	}
+	c.CertKey = ck
+
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (c *EtcdMetricClientCertKey) Name() string {
+	return "Certificate (etcd metric client)"
+}
+
+// Load loads the cert/key pair from disk, if present.
+func (c *EtcdMetricClientCertKey) Load(f asset.FileFetcher) (bool, error) {
+	ck, ok, err := loadCertKey(f, etcdMetricClientCertFilename, etcdMetricClientKeyFilename)
+	if err != nil || !ok {
+		return false, err
+	}
+	c.CertKey = ck
+	return true, nil
+}
+
+// Files returns the files generated by the asset.
+func (c *EtcdMetricClientCertKey) Files() []*asset.File {
+	return []*asset.File{
+		{Filename: etcdMetricClientCertFilename, Data: c.Cert()},
+		{Filename: etcdMetricClientKeyFilename, Data: c.Key()},
+	}
+}
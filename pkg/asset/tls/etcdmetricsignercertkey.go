@@ -0,0 +1,92 @@
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+const (
+	etcdMetricSignerCertFilename = "tls/etcd-metric-signer.crt"
+	etcdMetricSignerKeyFilename  = "tls/etcd-metric-signer.key"
+)
+
+var _ asset.WritableAsset = (*EtcdMetricSignerCertKey)(nil)
+
+// EtcdMetricSignerCertKey is the asset that generates the etcd-metric-signer
+// key/cert pair, a serving certificate presented by etcd's metrics listener
+// so Prometheus can scrape it over TLS.
+type EtcdMetricSignerCertKey struct {
+	CertKey
+}
+
+// Dependencies returns the dependency of the this asset.
+func (c *EtcdMetricSignerCertKey) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&EtcdMetricCA{},
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the cert/key pair.
+func (c *EtcdMetricSignerCertKey) Generate(parents asset.Parents) error {
+	ca := &EtcdMetricCA{}
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(ca, installConfig)
+
+	clusterName := installConfig.Config.ObjectMeta.Name
+	baseDomain := installConfig.Config.BaseDomain
+
+	dnsNames := []string{
+		"localhost",
+		"etcd.kube-system.svc",
+		"etcd.kube-system.svc.cluster.local",
+	}
+	for i := 0; i < installConfig.Config.MasterCount(); i++ {
+		dnsNames = append(dnsNames, fmt.Sprintf("%s-etcd-%d.%s", clusterName, i, baseDomain))
+	}
+
+	cfg := &CertCfg{
+		Subject:      pkix.Name{CommonName: "etcd-metric-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		Validity:     ValidityTenYears,
+		DNSNames:     dnsNames,
+	}
+
+	ck, err := genCertKey(cfg, &ca.CertKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate etcd-metric-signer cert")
+	}
+	c.CertKey = ck
+
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (c *EtcdMetricSignerCertKey) Name() string {
+	return "Certificate (etcd metric signer)"
+}
+
+// Load loads the cert/key pair from disk, if present.
+func (c *EtcdMetricSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	ck, ok, err := loadCertKey(f, etcdMetricSignerCertFilename, etcdMetricSignerKeyFilename)
+	if err != nil || !ok {
+		return false, err
+	}
+	c.CertKey = ck
+	return true, nil
+}
+
+// Files returns the files generated by the asset.
+func (c *EtcdMetricSignerCertKey) Files() []*asset.File {
+	return []*asset.File{
+		{Filename: etcdMetricSignerCertFilename, Data: c.Cert()},
+		{Filename: etcdMetricSignerKeyFilename, Data: c.Key()},
+	}
+}
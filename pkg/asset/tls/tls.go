@@ -0,0 +1,168 @@
+// Package tls generates the key pairs and x509 certificates used by the
+// bootstrap and installed cluster.
+package tls
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	keySize = 2048
+
+	// ValidityTenYears is the validity used for the long-lived certificate
+	// authorities generated by the installer.
+	ValidityTenYears = time.Hour * 24 * 365 * 10
+)
+
+// CertCfg holds the parameters used to generate a certificate.
+type CertCfg struct {
+	Subject      pkix.Name
+	KeyUsages    x509.KeyUsage
+	ExtKeyUsages []x509.ExtKeyUsage
+	Validity     time.Duration
+	IsCA         bool
+	DNSNames     []string
+	IPAddresses  []net.IP
+}
+
+// CertKey is embedded by the tls assets that expose a generated
+// certificate/key pair.
+type CertKey struct {
+	certRaw []byte
+	keyRaw  []byte
+}
+
+// Cert returns the PEM-encoded certificate.
+func (c *CertKey) Cert() []byte {
+	return c.certRaw
+}
+
+// Key returns the PEM-encoded private key.
+func (c *CertKey) Key() []byte {
+	return c.keyRaw
+}
+
+// genCA generates a new self-signed certificate authority.
+func genCA(cfg *CertCfg) (CertKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return CertKey{}, errors.Wrap(err, "failed to generate CA key")
+	}
+
+	cert, err := genCert(cfg, key, nil, nil)
+	if err != nil {
+		return CertKey{}, errors.Wrap(err, "failed to generate CA certificate")
+	}
+
+	return CertKey{certRaw: pemEncodeCert(cert), keyRaw: pemEncodeKey(key)}, nil
+}
+
+// genCertKey generates a new certificate/key pair signed by the given CA.
+func genCertKey(cfg *CertCfg, ca *CertKey) (CertKey, error) {
+	caCert, caKey, err := ca.parse()
+	if err != nil {
+		return CertKey{}, errors.Wrap(err, "failed to parse signing CA")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return CertKey{}, errors.Wrap(err, "failed to generate key")
+	}
+
+	cert, err := genCert(cfg, key, caCert, caKey)
+	if err != nil {
+		return CertKey{}, errors.Wrap(err, "failed to generate certificate")
+	}
+
+	return CertKey{certRaw: pemEncodeCert(cert), keyRaw: pemEncodeKey(key)}, nil
+}
+
+func genCert(cfg *CertCfg, key *rsa.PrivateKey, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate serial number")
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               cfg.Subject,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(cfg.Validity),
+		KeyUsage:              cfg.KeyUsages,
+		ExtKeyUsage:           cfg.ExtKeyUsages,
+		BasicConstraintsValid: true,
+		IsCA:                  cfg.IsCA,
+		DNSNames:              cfg.DNSNames,
+		IPAddresses:           cfg.IPAddresses,
+	}
+	if cfg.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	parent, signer := template, key
+	if caCert != nil {
+		parent, signer = caCert, caKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+func (c *CertKey) parse() (*x509.Certificate, *rsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(c.certRaw)
+	if certBlock == nil {
+		return nil, nil, errors.New("failed to decode certificate")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse certificate")
+	}
+
+	keyBlock, _ := pem.Decode(c.keyRaw)
+	if keyBlock == nil {
+		return nil, nil, errors.New("failed to decode key")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse key")
+	}
+
+	return cert, key, nil
+}
+
+func pemEncodeCert(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func pemEncodeKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// loadCertKey loads a cert/key pair from the asset file fetcher. It returns
+// a zero CertKey, false if either file is missing.
+func loadCertKey(f asset.FileFetcher, certPath, keyPath string) (CertKey, bool, error) {
+	certFile, err := f.FetchByName(certPath)
+	if err != nil {
+		return CertKey{}, false, nil
+	}
+	keyFile, err := f.FetchByName(keyPath)
+	if err != nil {
+		return CertKey{}, false, nil
+	}
+	return CertKey{certRaw: certFile.Data, keyRaw: keyFile.Data}, true, nil
+}
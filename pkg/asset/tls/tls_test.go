@@ -0,0 +1,109 @@
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+)
+
+// parseCert decodes a PEM-encoded certificate as produced by genCA/genCertKey
+// so tests can assert on the resulting x509.Certificate fields.
+func parseCert(t *testing.T, raw []byte) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		t.Fatalf("failed to decode PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+// TestEtcdMetricCertChain exercises the exact CertCfg shapes that
+// EtcdMetricCA, EtcdMetricClientCertKey and EtcdMetricSignerCertKey build,
+// covering the CA/leaf generation logic those three assets depend on
+// (including the etcd-metric-signer SAN list, since an empty DNSNames here
+// would silently break hostname verification for scrapers).
+func TestEtcdMetricCertChain(t *testing.T) {
+	caCfg := &CertCfg{
+		Subject:   pkix.Name{CommonName: "etcd-metric-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		Validity:  ValidityTenYears,
+		IsCA:      true,
+	}
+	ca, err := genCA(caCfg)
+	if err != nil {
+		t.Fatalf("genCA() error = %v", err)
+	}
+
+	caCert := parseCert(t, ca.Cert())
+	if !caCert.IsCA {
+		t.Errorf("CA certificate IsCA = false, want true")
+	}
+	if caCert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		t.Errorf("CA certificate missing KeyUsageCertSign")
+	}
+
+	t.Run("client cert", func(t *testing.T) {
+		cfg := &CertCfg{
+			Subject:      pkix.Name{CommonName: "etcd-metric-client", OrganizationalUnit: []string{"openshift"}},
+			KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+			ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			Validity:     ValidityTenYears,
+		}
+		ck, err := genCertKey(cfg, &ca)
+		if err != nil {
+			t.Fatalf("genCertKey() error = %v", err)
+		}
+
+		cert := parseCert(t, ck.Cert())
+		if cert.IsCA {
+			t.Errorf("client certificate IsCA = true, want false")
+		}
+		if err := cert.CheckSignatureFrom(caCert); err != nil {
+			t.Errorf("client certificate is not signed by the etcd-metric CA: %v", err)
+		}
+		if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageClientAuth {
+			t.Errorf("client certificate ExtKeyUsage = %v, want [ClientAuth]", cert.ExtKeyUsage)
+		}
+	})
+
+	t.Run("signer cert has the DNS SANs scrapers verify against", func(t *testing.T) {
+		dnsNames := []string{
+			"localhost",
+			"etcd.kube-system.svc",
+			"etcd.kube-system.svc.cluster.local",
+			"test-cluster-etcd-0.example.com",
+		}
+		cfg := &CertCfg{
+			Subject:      pkix.Name{CommonName: "etcd-metric-signer", OrganizationalUnit: []string{"openshift"}},
+			KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+			ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			Validity:     ValidityTenYears,
+			DNSNames:     dnsNames,
+		}
+		ck, err := genCertKey(cfg, &ca)
+		if err != nil {
+			t.Fatalf("genCertKey() error = %v", err)
+		}
+
+		cert := parseCert(t, ck.Cert())
+		if err := cert.CheckSignatureFrom(caCert); err != nil {
+			t.Errorf("signer certificate is not signed by the etcd-metric CA: %v", err)
+		}
+		if len(cert.DNSNames) != len(dnsNames) {
+			t.Fatalf("DNSNames = %v, want %v", cert.DNSNames, dnsNames)
+		}
+		for i, want := range dnsNames {
+			if cert.DNSNames[i] != want {
+				t.Errorf("DNSNames[%d] = %q, want %q", i, cert.DNSNames[i], want)
+			}
+		}
+		if err := cert.VerifyHostname("test-cluster-etcd-0.example.com"); err != nil {
+			t.Errorf("VerifyHostname() error = %v, want nil", err)
+		}
+	})
+}